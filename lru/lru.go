@@ -0,0 +1,160 @@
+// Package lru provides a thread-safe fixed size cache, built on top of the
+// approximate simplelru.LRU.
+package lru
+
+import (
+	"sync"
+
+	"github.com/bpowers/approx-lru/simplelru"
+)
+
+// Cache is a thread-safe fixed size LRU cache.
+type Cache[K comparable, V any] struct {
+	lru     *simplelru.LRU[K, V]
+	lock    sync.RWMutex
+	onEvict simplelru.EvictCallback[K, V]
+
+	// buffers for evicted entries accumulated while the lock is held;
+	// onEvict is invoked for each of these after the lock is released so
+	// that callbacks are free to call back into the cache.
+	evictedKeys   []K
+	evictedValues []V
+}
+
+// New creates an LRU cache of the given size.
+func New[K comparable, V any](size int) (*Cache[K, V], error) {
+	return NewWithEvict[K, V](size, nil)
+}
+
+// NewWithEvict constructs a fixed size cache with the given eviction
+// callback.
+func NewWithEvict[K comparable, V any](size int, onEvict func(key K, value V)) (*Cache[K, V], error) {
+	c := &Cache[K, V]{
+		onEvict: onEvict,
+	}
+	if onEvict != nil {
+		c.initEvictBuffers()
+		onEvict = c.onEvicted
+	}
+	lru, err := simplelru.NewLRU[K, V](size, onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.lru = lru
+	return c, nil
+}
+
+func (c *Cache[K, V]) initEvictBuffers() {
+	c.evictedKeys = make([]K, 0, 16)
+	c.evictedValues = make([]V, 0, 16)
+}
+
+// onEvicted is registered as the simplelru.LRU's EvictCallback when this
+// cache has its own onEvict set; it must only be called while c.lock is
+// held, and buffers rather than invokes the user's callback so that the
+// callback never runs with the lock held.
+func (c *Cache[K, V]) onEvicted(k K, v V) {
+	c.evictedKeys = append(c.evictedKeys, k)
+	c.evictedValues = append(c.evictedValues, v)
+}
+
+// finishCallback drains the evicted buffers and invokes onEvict for each
+// entry. Must be called after c.lock has been released; it re-takes the
+// lock only to swap the buffers out, and invokes onEvict without it.
+//
+// It takes ownership of the current buffers and installs fresh ones before
+// invoking any callback, rather than truncating c.evictedKeys/Values in
+// place after the loop: onEvict is free to call back into the cache (e.g.
+// an Add that evicts another entry), which calls finishCallback again,
+// and that nested call must see an empty buffer rather than re-draining
+// entries the outer call is still iterating over.
+func (c *Cache[K, V]) finishCallback() {
+	if c.onEvict == nil {
+		return
+	}
+	c.lock.Lock()
+	keys, values := c.evictedKeys, c.evictedValues
+	if len(keys) > 0 {
+		c.initEvictBuffers()
+	}
+	c.lock.Unlock()
+	for i := range keys {
+		c.onEvict(keys[i], values[i])
+	}
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
+	c.lock.Lock()
+	evicted = c.lru.Add(key, value)
+	c.lock.Unlock()
+	c.finishCallback()
+	return evicted
+}
+
+// Get looks up a key's value from the cache.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	value, ok = c.lru.Get(key)
+	c.lock.Unlock()
+	c.finishCallback()
+	return value, ok
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness. Takes the full lock, not just a read lock: per
+// simplelru.LRU's doc comment, once a TTL is in play Contains can mutate
+// the cache (deleting an expired entry and firing onEvict).
+func (c *Cache[K, V]) Contains(key K) (ok bool) {
+	c.lock.Lock()
+	ok = c.lru.Contains(key)
+	c.lock.Unlock()
+	c.finishCallback()
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the "recently used"-ness of the key. Takes the full lock, not just a
+// read lock: per simplelru.LRU's doc comment, once a TTL is in play Peek
+// can mutate the cache (deleting an expired entry and firing onEvict).
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	value, ok = c.lru.Peek(key)
+	c.lock.Unlock()
+	c.finishCallback()
+	return value, ok
+}
+
+// Remove removes the provided key from the cache, returning if the key was
+// contained.
+func (c *Cache[K, V]) Remove(key K) (present bool) {
+	c.lock.Lock()
+	present = c.lru.Remove(key)
+	c.lock.Unlock()
+	c.finishCallback()
+	return present
+}
+
+// Purge is used to completely clear the cache.
+func (c *Cache[K, V]) Purge() {
+	c.lock.Lock()
+	c.lru.Purge()
+	c.lock.Unlock()
+	c.finishCallback()
+}
+
+// Resize changes the cache size.
+func (c *Cache[K, V]) Resize(size int) (evicted int) {
+	c.lock.Lock()
+	evicted = c.lru.Resize(size)
+	c.lock.Unlock()
+	c.finishCallback()
+	return evicted
+}
+
+// Len returns the number of items in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,47 @@
+package lru
+
+import "testing"
+
+func TestCacheReentrantEvictCallback(t *testing.T) {
+	var cache *Cache[int, int]
+	var sawKeys []int
+
+	cache, err := NewWithEvict[int, int](2, func(key, value int) {
+		sawKeys = append(sawKeys, key)
+		// A callback that calls back into the cache must not deadlock
+		// against c.lock.
+		cache.Contains(999)
+	})
+	if err != nil {
+		t.Fatalf("NewWithEvict: %v", err)
+	}
+
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+	cache.Add(3, 3) // forces an eviction; onEvict re-enters the cache
+
+	if len(sawKeys) != 1 {
+		t.Fatalf("expected exactly one eviction callback, got %d", len(sawKeys))
+	}
+}
+
+func TestCacheBasic(t *testing.T) {
+	c, err := New[string, int](2)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1, got %v ok=%v", v, ok)
+	}
+	if !c.Contains("b") {
+		t.Fatalf("expected b present")
+	}
+	if !c.Remove("b") {
+		t.Fatalf("expected b removed")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("expected len 1, got %d", c.Len())
+	}
+}
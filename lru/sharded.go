@@ -0,0 +1,143 @@
+package lru
+
+import (
+	"errors"
+	"hash/maphash"
+	"math/bits"
+)
+
+// ShardedCache is a concurrent cache made up of shardCount independent,
+// mutex-guarded Cache shards, each owning its own slice of the keyspace.
+// Routing keys to a shard trades a small amount of cross-cache approximation
+// (eviction decisions are made per-shard, not over the whole keyspace) for
+// linear scalability under contention, which fits naturally alongside the
+// random-probe eviction this module already uses.
+type ShardedCache[K comparable, V any] struct {
+	mask   uint64
+	hash   func(K) uint64
+	shards []*Cache[K, V]
+}
+
+// NewSharded constructs a ShardedCache holding approximately totalSize
+// entries spread evenly across shardCount shards (rounded up to the next
+// power of two so key routing is a mask instead of a modulo). hash picks
+// the shard for a key; callers with string keys can use NewShardedString
+// instead to get a hash/maphash-backed hasher for free.
+//
+// There's no single constructor that auto-detects string/[]byte keys the
+// way hash/maphash itself overloads on type: Go's comparable constraint on
+// K rules out []byte outright, and detecting "K happens to be string" at
+// the generic-function level would need a type switch on top of a type
+// parameter, which doesn't buy anything NewShardedString doesn't already
+// give callers directly.
+func NewSharded[K comparable, V any](totalSize, shardCount int, hash func(K) uint64, onEvict func(key K, value V)) (*ShardedCache[K, V], error) {
+	if totalSize <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	if shardCount <= 0 {
+		return nil, errors.New("must provide a positive shard count")
+	}
+	if hash == nil {
+		return nil, errors.New("must provide a hash function")
+	}
+
+	n := nextPowerOfTwo(shardCount)
+	shardSize := ceilDiv(totalSize, n)
+
+	c := &ShardedCache[K, V]{
+		mask:   uint64(n - 1),
+		hash:   hash,
+		shards: make([]*Cache[K, V], n),
+	}
+	for i := range c.shards {
+		shard, err := NewWithEvict[K, V](shardSize, onEvict)
+		if err != nil {
+			return nil, err
+		}
+		c.shards[i] = shard
+	}
+	return c, nil
+}
+
+// NewShardedString constructs a ShardedCache keyed by string, hashing keys
+// with hash/maphash rather than requiring the caller to supply a hash
+// function.
+func NewShardedString[V any](totalSize, shardCount int, onEvict func(key string, value V)) (*ShardedCache[string, V], error) {
+	seed := maphash.MakeSeed()
+	return NewSharded[string, V](totalSize, shardCount, func(k string) uint64 {
+		return maphash.String(seed, k)
+	}, onEvict)
+}
+
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+func ceilDiv(a, b int) int {
+	size := (a + b - 1) / b
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+func (c *ShardedCache[K, V]) shardFor(key K) *Cache[K, V] {
+	return c.shards[c.hash(key)&c.mask]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ShardedCache[K, V]) Add(key K, value V) (evicted bool) {
+	return c.shardFor(key).Add(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (c *ShardedCache[K, V]) Get(key K) (value V, ok bool) {
+	return c.shardFor(key).Get(key)
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the "recently used"-ness of the key.
+func (c *ShardedCache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.shardFor(key).Peek(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness.
+func (c *ShardedCache[K, V]) Contains(key K) (ok bool) {
+	return c.shardFor(key).Contains(key)
+}
+
+// Remove removes the provided key from the cache, returning if the key was
+// contained.
+func (c *ShardedCache[K, V]) Remove(key K) (present bool) {
+	return c.shardFor(key).Remove(key)
+}
+
+// Len returns the number of items across all shards.
+func (c *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Purge is used to completely clear every shard.
+func (c *ShardedCache[K, V]) Purge() {
+	for _, s := range c.shards {
+		s.Purge()
+	}
+}
+
+// Resize changes each shard's size so that the cache holds approximately
+// totalSize entries in aggregate.
+func (c *ShardedCache[K, V]) Resize(totalSize int) (evicted int) {
+	shardSize := ceilDiv(totalSize, len(c.shards))
+	for _, s := range c.shards {
+		evicted += s.Resize(shardSize)
+	}
+	return evicted
+}
@@ -0,0 +1,117 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestShardedCacheBasicRouting(t *testing.T) {
+	c, err := NewShardedString[int](100, 4, nil)
+	if err != nil {
+		t.Fatalf("NewShardedString: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		c.Add(fmt.Sprintf("k%d", i), i)
+	}
+	if c.Len() == 0 {
+		t.Fatalf("expected entries to have been added")
+	}
+
+	for i := 0; i < 50; i++ {
+		v, ok := c.Get(fmt.Sprintf("k%d", i))
+		if !ok || v != i {
+			t.Fatalf("expected k%d=%d, got %v ok=%v", i, i, v, ok)
+		}
+	}
+
+	if !c.Contains("k10") {
+		t.Fatalf("expected k10 present")
+	}
+	if !c.Remove("k10") {
+		t.Fatalf("expected k10 to be removed")
+	}
+	if c.Contains("k10") {
+		t.Fatalf("expected k10 absent after Remove")
+	}
+}
+
+func TestShardedCacheEvictsPerShard(t *testing.T) {
+	var evicted int
+	// a single shard collapses this to a plain per-shard LRU of size 2,
+	// making eviction deterministic to assert on.
+	c, err := NewShardedString[int](2, 1, func(key string, value int) {
+		evicted++
+	})
+	if err != nil {
+		t.Fatalf("NewShardedString: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+
+	if evicted != 1 {
+		t.Fatalf("expected exactly one eviction, got %d", evicted)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected len 2, got %d", c.Len())
+	}
+}
+
+func TestShardedCacheResizeRecomputesShardSize(t *testing.T) {
+	// a single shard makes eviction deterministic, since with more than
+	// one shard the hash distribution of 8 keys across N shards is not
+	// guaranteed to leave every shard under its capacity.
+	c, err := NewShardedString[int](8, 1, nil)
+	if err != nil {
+		t.Fatalf("NewShardedString: %v", err)
+	}
+	for i := 0; i < 8; i++ {
+		c.Add(fmt.Sprintf("k%d", i), i)
+	}
+	if c.Len() != 8 {
+		t.Fatalf("expected len 8 before resize, got %d", c.Len())
+	}
+
+	evicted := c.Resize(4)
+	if evicted == 0 {
+		t.Fatalf("expected shrinking the cache to evict entries")
+	}
+	if c.Len() != 4 {
+		t.Fatalf("expected len 4 after resize, got %d", c.Len())
+	}
+}
+
+func TestShardedCacheConcurrentAccess(t *testing.T) {
+	c, err := NewShardedString[int](256, 8, nil)
+	if err != nil {
+		t.Fatalf("NewShardedString: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("g%d-k%d", g, i%32)
+				c.Add(key, i)
+				c.Get(key)
+				c.Contains(key)
+				c.Peek(key)
+				if i%7 == 0 {
+					c.Remove(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	_ = c.Len()
+	c.Purge()
+	if c.Len() != 0 {
+		t.Fatalf("expected empty cache after Purge, got %d", c.Len())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"math/rand"
+	"sort"
 	"time"
 )
 
@@ -21,10 +22,16 @@ func newRand() *rand.Rand {
 // EvictCallback is used to get a callback when a cache entry is evicted
 type EvictCallback[K comparable, V any] func(key K, value V)
 
-// LRU implements a non-thread safe fixed size LRU cache
+// LRU implements a non-thread safe fixed size LRU cache.
+//
+// Note for anyone wrapping LRU with a lock: once a TTL is in play, Contains
+// and Peek are no longer read-only despite their names — an expired entry
+// is removed (and onEvict fired) on the spot, so a wrapper must take a full
+// lock around them, not a read lock.
 type LRU[K comparable, V any] struct {
 	rng     rand.Rand
 	size    int
+	ttl     time.Duration
 	data    []entry[K, V]
 	items   map[K]int
 	onEvict EvictCallback[K, V]
@@ -34,9 +41,15 @@ const randomProbes = 8
 
 // entry is used to hold a value in the evictList
 type entry[K comparable, V any] struct {
-	lastUsed int64
-	key      K
-	value    V
+	lastUsed  int64
+	expiresAt int64
+	key       K
+	value     V
+}
+
+// isExpired reports whether ent's TTL (if any) has elapsed as of now.
+func isExpired[K comparable, V any](ent entry[K, V], now int64) bool {
+	return ent.expiresAt != 0 && now >= ent.expiresAt
 }
 
 // NewLRU constructs an LRU of the given size
@@ -54,6 +67,18 @@ func NewLRU[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*LRU[K,
 	return c, nil
 }
 
+// NewLRUWithTTL constructs an LRU of the given size whose entries expire
+// ttl after they are added, unless overridden per-item via AddWithTTL. A
+// ttl of 0 means entries never expire, matching NewLRU.
+func NewLRUWithTTL[K comparable, V any](size int, ttl time.Duration, onEvict EvictCallback[K, V]) (*LRU[K, V], error) {
+	c, err := NewLRU[K, V](size, onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.ttl = ttl
+	return c, nil
+}
+
 // Purge is used to completely clear the cache.
 func (c *LRU[K, V]) Purge() {
 	for k, i := range c.items {
@@ -77,16 +102,30 @@ func (c *LRU[K, V]) shuffle() {
 
 // Add adds a value to the cache.  Returns true if an eviction occurred.
 func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
+	return c.AddWithTTL(key, value, c.ttl)
+}
+
+// AddWithTTL adds a value to the cache with a per-item TTL, overriding the
+// cache's default (if any). A ttl of 0 means the entry never expires.
+// Returns true if an eviction occurred.
+func (c *LRU[K, V]) AddWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	now := time.Now().UnixNano()
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = now + int64(ttl)
+	}
+
 	// Check for existing item
 	if i, ok := c.items[key]; ok {
 		entry := &c.data[i]
-		entry.lastUsed = time.Now().UnixNano()
+		entry.lastUsed = now
+		entry.expiresAt = expiresAt
 		entry.value = value
 		return false
 	}
 
 	// Add new item
-	ent := entry[K, V]{time.Now().UnixNano(), key, value}
+	ent := entry[K, V]{lastUsed: now, expiresAt: expiresAt, key: key, value: value}
 
 	if len(c.data) < c.size {
 		i := len(c.data)
@@ -98,38 +137,64 @@ func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
 		if len(c.data) == c.size {
 			c.shuffle()
 		}
-	} else {
+	} else if i, ok := c.evictForOverwrite(); ok {
 		evicted = true
-		i := c.removeOldest()
 		c.data[i] = ent
 		c.items[key] = i
+	} else {
+		// No victim was available to overwrite (e.g. the cache was
+		// just Resize'd down to 0 and is empty) -- append instead of
+		// indexing a nonexistent slot.
+		i := len(c.data)
+		c.data = append(c.data, ent)
+		c.items[key] = i
 	}
 
 	return
 }
 
-// Get looks up a key's value from the cache.
+// Get looks up a key's value from the cache. An expired entry is treated as
+// absent and is evicted lazily, firing onEvict.
 func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
 	if i, ok := c.items[key]; ok {
-		entry := &c.data[i]
-		entry.lastUsed = time.Now().UnixNano()
-		return entry.value, true
+		ent := &c.data[i]
+		now := time.Now().UnixNano()
+		if isExpired(*ent, now) {
+			c.removeElement(i, *ent)
+			return value, false
+		}
+		ent.lastUsed = now
+		return ent.value, true
 	}
 	return
 }
 
 // Contains checks if a key is in the cache, without updating the recent-ness
-// or deleting it for being stale.
+// of a live entry. An expired entry is treated as absent and is evicted
+// lazily, firing onEvict.
 func (c *LRU[K, V]) Contains(key K) (ok bool) {
-	_, ok = c.items[key]
-	return ok
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	if isExpired(c.data[i], time.Now().UnixNano()) {
+		c.removeElement(i, c.data[i])
+		return false
+	}
+	return true
 }
 
 // Peek returns the key value (or undefined if not found) without updating
-// the "recently used"-ness of the key.
+// the "recently used"-ness of the key. An expired entry is treated as
+// absent and is evicted lazily, firing onEvict.
 func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
 	if i, ok := c.items[key]; ok {
-		return c.data[i].value, true
+		ent := c.data[i]
+		if isExpired(ent, time.Now().UnixNano()) {
+			c.removeElement(i, ent)
+			return value, false
+		}
+		return ent.value, true
 	}
 	return value, false
 }
@@ -156,24 +221,36 @@ func (c *LRU[K, V]) Resize(size int) (evicted int) {
 		diff = 0
 	}
 	for i := 0; i < diff; i++ {
-		c.removeOldest()
+		if off, ok := c.sampleOldest(); ok {
+			c.removeElement(off, c.data[off])
+		}
 	}
 	c.size = size
 	return diff
 }
 
-// removeOldest removes the oldest item from the cache.
-func (c *LRU[K, V]) removeOldest() (off int) {
+// sampleOldest finds the offset of an approximately-oldest entry without
+// removing it, using the random-probe heuristic that eviction relies on:
+// entries that have already expired are preferred over the
+// oldest-lastUsed-of-the-sample fallback.
+func (c *LRU[K, V]) sampleOldest() (off int, ok bool) {
 	size := c.Len()
 	if size <= 0 {
-		return -1
+		return -1, false
 	}
+	now := time.Now().UnixNano()
 	base := c.rng.Intn(size)
 	oldestOff := base
 	oldest := c.data[base]
+	if isExpired(oldest, now) {
+		return oldestOff, true
+	}
 	for j := 1; j < randomProbes; j++ {
 		off := (base + j) % size
 		candidate := &c.data[off]
+		if isExpired(*candidate, now) {
+			return off, true
+		}
 		if candidate.lastUsed < oldest.lastUsed {
 			oldestOff = off
 			oldest = *candidate
@@ -181,15 +258,157 @@ func (c *LRU[K, V]) removeOldest() (off int) {
 	}
 
 	// we could have found an empty slot
-	if oldest.lastUsed != 0 {
-		c.removeElement(oldestOff, oldest)
+	if oldest.lastUsed == 0 {
+		return oldestOff, false
+	}
+	return oldestOff, true
+}
+
+// evictForOverwrite removes the bookkeeping for an approximately-oldest
+// entry (deleting it from items and firing onEvict) but leaves its slot in
+// data untouched, on the assumption that the caller is about to overwrite
+// that slot with the entry it's inserting. This is cheaper than
+// removeElement's compaction, which only pays off when nothing is about to
+// be written back into the freed slot. ok is false if the cache is empty
+// and there was no victim to evict; the caller must not index off in that
+// case.
+func (c *LRU[K, V]) evictForOverwrite() (off int, ok bool) {
+	off, ok = c.sampleOldest()
+	if !ok {
+		return off, false
+	}
+	ent := c.data[off]
+	delete(c.items, ent.key)
+	if c.onEvict != nil {
+		c.onEvict(ent.key, ent.value)
 	}
-	return oldestOff
+	return off, true
 }
 
-// removeElement is used to remove a given list element from the cache
+// sampleOldestLive is like sampleOldest, but for callers that hand the
+// sampled entry back to the user rather than immediately overwriting it:
+// an expired candidate is treated as absent, exactly as Get/Peek/Contains
+// do, rather than reported as the live "oldest" entry. The expired
+// candidate is evicted (firing onEvict) before returning ok=false.
+func (c *LRU[K, V]) sampleOldestLive() (off int, ok bool) {
+	off, ok = c.sampleOldest()
+	if !ok {
+		return off, false
+	}
+	if isExpired(c.data[off], time.Now().UnixNano()) {
+		c.removeElement(off, c.data[off])
+		return off, false
+	}
+	return off, true
+}
+
+// GetOldest returns the approximately oldest live entry in the cache,
+// without removing it or updating its recent-ness. An expired entry is
+// treated as absent and is evicted lazily, firing onEvict.
+func (c *LRU[K, V]) GetOldest() (key K, value V, ok bool) {
+	off, ok := c.sampleOldestLive()
+	if !ok {
+		return key, value, false
+	}
+	ent := c.data[off]
+	return ent.key, ent.value, true
+}
+
+// RemoveOldest removes the approximately oldest live entry from the cache,
+// returning it. An expired entry is treated as absent: it is still evicted
+// lazily (firing onEvict), but is not reported as the removed entry.
+func (c *LRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	off, ok := c.sampleOldestLive()
+	if !ok {
+		return key, value, false
+	}
+	ent := c.data[off]
+	c.removeElement(off, ent)
+	return ent.key, ent.value, true
+}
+
+// evictExpired sweeps every entry for TTL expiry, removing (and firing
+// onEvict for) any that have passed. Expired keys are collected in a
+// read-only pass before any are removed: removeElement compacts data by
+// moving its last live entry into the freed slot, which would reassign the
+// index of an as-yet-unvisited map entry out from under an in-progress
+// range over c.items.
+func (c *LRU[K, V]) evictExpired() {
+	now := time.Now().UnixNano()
+	var expiredKeys []K
+	for k, i := range c.items {
+		if isExpired(c.data[i], now) {
+			expiredKeys = append(expiredKeys, k)
+		}
+	}
+	for _, k := range expiredKeys {
+		if i, ok := c.items[k]; ok {
+			c.removeElement(i, c.data[i])
+		}
+	}
+}
+
+// sortedIndexes returns the indexes into c.data for all live entries,
+// sorted by lastUsed ascending (oldest first). Expired entries are treated
+// as absent, same as Get/Peek/Contains, and are evicted lazily (firing
+// onEvict) rather than included.
+func (c *LRU[K, V]) sortedIndexes() []int {
+	c.evictExpired()
+	idxs := make([]int, 0, len(c.items))
+	for _, i := range c.items {
+		idxs = append(idxs, i)
+	}
+	sort.Slice(idxs, func(a, b int) bool {
+		return c.data[idxs[a]].lastUsed < c.data[idxs[b]].lastUsed
+	})
+	return idxs
+}
+
+// Keys returns a snapshot of the cache's keys in approximate
+// least-recently-used-first order.
+func (c *LRU[K, V]) Keys() []K {
+	idxs := c.sortedIndexes()
+	keys := make([]K, 0, len(idxs))
+	for _, i := range idxs {
+		keys = append(keys, c.data[i].key)
+	}
+	return keys
+}
+
+// Values returns a snapshot of the cache's values in approximate
+// least-recently-used-first order.
+func (c *LRU[K, V]) Values() []V {
+	idxs := c.sortedIndexes()
+	values := make([]V, 0, len(idxs))
+	for _, i := range idxs {
+		values = append(values, c.data[i].value)
+	}
+	return values
+}
+
+// Range calls f for each entry in the cache in approximate
+// least-recently-used-first order, stopping early if f returns false.
+func (c *LRU[K, V]) Range(f func(key K, value V) bool) {
+	for _, i := range c.sortedIndexes() {
+		ent := c.data[i]
+		if !f(ent.key, ent.value) {
+			return
+		}
+	}
+}
+
+// removeElement is used to remove a given list element from the cache. The
+// vacated slot is filled by moving data's last entry into its place (order
+// doesn't matter for this cache) so that live entries stay packed into
+// data[0:Len()), which the random-probe heuristic in sampleOldest assumes.
 func (c *LRU[K, V]) removeElement(i int, ent entry[K, V]) {
-	c.data[i] = entry[K, V]{}
+	last := len(c.data) - 1
+	if i != last {
+		c.data[i] = c.data[last]
+		c.items[c.data[i].key] = i
+	}
+	c.data[last] = entry[K, V]{}
+	c.data = c.data[:last]
 	delete(c.items, ent.key)
 	if c.onEvict != nil {
 		c.onEvict(ent.key, ent.value)
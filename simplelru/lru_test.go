@@ -0,0 +1,186 @@
+package simplelru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUTTLExpiresEntries(t *testing.T) {
+	var evicted []string
+	c, err := NewLRUWithTTL[string, int](2, 10*time.Millisecond, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	if err != nil {
+		t.Fatalf("NewLRUWithTTL: %v", err)
+	}
+
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected expired entry to be absent from Get")
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("expected onEvict to fire for expired entry, got %v", evicted)
+	}
+}
+
+func TestLRUAddWithTTLOverridesDefault(t *testing.T) {
+	c, err := NewLRUWithTTL[string, int](2, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewLRUWithTTL: %v", err)
+	}
+
+	c.AddWithTTL("short", 1, 10*time.Millisecond)
+	c.Add("long", 2)
+	time.Sleep(20 * time.Millisecond)
+
+	if c.Contains("short") {
+		t.Fatalf("expected short-lived entry to have expired")
+	}
+	if !c.Contains("long") {
+		t.Fatalf("expected default-TTL entry to still be present")
+	}
+}
+
+func TestLRUGetOldestTreatsExpiredAsAbsent(t *testing.T) {
+	var evicted []string
+	c, err := NewLRUWithTTL[string, int](1, 10*time.Millisecond, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	if err != nil {
+		t.Fatalf("NewLRUWithTTL: %v", err)
+	}
+
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, _, ok := c.GetOldest(); ok {
+		t.Fatalf("expected GetOldest to treat expired entry as absent")
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("expected GetOldest to lazily evict the expired entry, got %v", evicted)
+	}
+}
+
+func TestLRURemoveOldestTreatsExpiredAsAbsent(t *testing.T) {
+	c, err := NewLRUWithTTL[string, int](1, 10*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("NewLRUWithTTL: %v", err)
+	}
+
+	c.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, _, ok := c.RemoveOldest(); ok {
+		t.Fatalf("expected RemoveOldest to treat expired entry as absent")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected expired entry to have been swept, len=%d", c.Len())
+	}
+}
+
+func TestLRUKeysValuesRangeExcludeExpired(t *testing.T) {
+	var evicted []string
+	c, err := NewLRUWithTTL[string, int](2, 10*time.Millisecond, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	if err != nil {
+		t.Fatalf("NewLRUWithTTL: %v", err)
+	}
+
+	c.AddWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	c.AddWithTTL("b", 2, time.Hour)
+
+	if keys := c.Keys(); len(keys) != 1 || keys[0] != "b" {
+		t.Fatalf("expected Keys() to exclude expired entry, got %v", keys)
+	}
+	if values := c.Values(); len(values) != 1 || values[0] != 2 {
+		t.Fatalf("expected Values() to exclude expired entry, got %v", values)
+	}
+
+	var ranged []string
+	c.Range(func(key string, value int) bool {
+		ranged = append(ranged, key)
+		return true
+	})
+	if len(ranged) != 1 || ranged[0] != "b" {
+		t.Fatalf("expected Range() to exclude expired entry, got %v", ranged)
+	}
+
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("expected expired entry to be lazily evicted, got %v", evicted)
+	}
+}
+
+func TestLRUOrderingReflectsRecentUse(t *testing.T) {
+	c, err := NewLRU[string, int](3, nil)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	c.Add("a", 1)
+	time.Sleep(time.Millisecond)
+	c.Add("b", 2)
+	time.Sleep(time.Millisecond)
+	c.Add("c", 3)
+	time.Sleep(time.Millisecond)
+
+	// Touching "a" makes it the most-recently-used, leaving "b" as the
+	// oldest.
+	c.Get("a")
+
+	wantKeys := []string{"b", "c", "a"}
+	if keys := c.Keys(); !equalSlices(keys, wantKeys) {
+		t.Fatalf("Keys() = %v, want %v", keys, wantKeys)
+	}
+
+	wantValues := []int{2, 3, 1}
+	if values := c.Values(); !equalSlices(values, wantValues) {
+		t.Fatalf("Values() = %v, want %v", values, wantValues)
+	}
+
+	var ranged []string
+	c.Range(func(key string, value int) bool {
+		ranged = append(ranged, key)
+		return true
+	})
+	if !equalSlices(ranged, wantKeys) {
+		t.Fatalf("Range() order = %v, want %v", ranged, wantKeys)
+	}
+
+	if key, value, ok := c.GetOldest(); !ok || key != "b" || value != 2 {
+		t.Fatalf("GetOldest() = (%v, %v, %v), want (b, 2, true)", key, value, ok)
+	}
+}
+
+func equalSlices[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLRUAddAfterResizeToZero(t *testing.T) {
+	c, err := NewLRU[string, int](2, nil)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Resize(0)
+
+	if c.Add("x", 10); c.Len() != 1 {
+		t.Fatalf("expected cache to contain the newly added entry, got len=%d", c.Len())
+	}
+	if v, ok := c.Get("x"); !ok || v != 10 {
+		t.Fatalf("expected Get(x) to find the entry just added, got (%v, %v)", v, ok)
+	}
+}
@@ -0,0 +1,331 @@
+package simplelru
+
+import (
+	"errors"
+	"sync"
+)
+
+// sieveNode is a node in the doubly-linked FIFO list used by SIEVE. head is
+// the most recently inserted node (prev == nil); tail is the oldest
+// (next == nil).
+type sieveNode[K comparable, V any] struct {
+	key     K
+	value   V
+	visited bool
+	prev    *sieveNode[K, V]
+	next    *sieveNode[K, V]
+}
+
+// SIEVE implements a non-thread safe fixed size cache using the SIEVE
+// eviction algorithm (https://sieve-cache.com): a FIFO queue with a single
+// "visited" bit per entry and a moving hand that sweeps from the tail
+// towards the head looking for an unvisited victim. Unlike LRU, a cache hit
+// only sets a bit and never splices the entry to a new position, so Get is
+// cheaper than LRU's at the cost of an approximate recency ordering.
+type SIEVE[K comparable, V any] struct {
+	size    int
+	len     int
+	head    *sieveNode[K, V]
+	tail    *sieveNode[K, V]
+	hand    *sieveNode[K, V]
+	items   map[K]*sieveNode[K, V]
+	onEvict EvictCallback[K, V]
+}
+
+// NewSIEVE constructs a SIEVE cache of the given size.
+func NewSIEVE[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*SIEVE[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	c := &SIEVE[K, V]{
+		size:    size,
+		items:   make(map[K]*sieveNode[K, V]),
+		onEvict: onEvict,
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *SIEVE[K, V]) Purge() {
+	for k, n := range c.items {
+		if c.onEvict != nil {
+			c.onEvict(k, n.value)
+		}
+	}
+	c.head = nil
+	c.tail = nil
+	c.hand = nil
+	c.len = 0
+	c.items = make(map[K]*sieveNode[K, V])
+}
+
+// pushFront inserts n at the head of the FIFO.
+func (c *SIEVE[K, V]) pushFront(n *sieveNode[K, V]) {
+	n.prev = nil
+	n.next = c.head
+	if c.head != nil {
+		c.head.prev = n
+	}
+	c.head = n
+	if c.tail == nil {
+		c.tail = n
+	}
+}
+
+// unlink removes n from the FIFO, repairing the hand if it pointed at n.
+func (c *SIEVE[K, V]) unlink(n *sieveNode[K, V]) {
+	if c.hand == n {
+		c.hand = n.prev
+	}
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		c.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		c.tail = n.prev
+	}
+	n.prev = nil
+	n.next = nil
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *SIEVE[K, V]) Add(key K, value V) (evicted bool) {
+	if n, ok := c.items[key]; ok {
+		n.value = value
+		return false
+	}
+
+	if c.len >= c.size {
+		c.evict()
+		evicted = true
+	}
+
+	n := &sieveNode[K, V]{key: key, value: value}
+	c.pushFront(n)
+	c.items[key] = n
+	c.len++
+
+	return evicted
+}
+
+// evict runs the SIEVE hand over the FIFO to find and remove a victim.
+func (c *SIEVE[K, V]) evict() {
+	o := c.hand
+	if o == nil {
+		o = c.tail
+	}
+	for o.visited {
+		o.visited = false
+		o = o.prev
+		if o == nil {
+			o = c.tail
+		}
+	}
+	c.hand = o.prev
+	c.unlink(o)
+	delete(c.items, o.key)
+	c.len--
+	if c.onEvict != nil {
+		c.onEvict(o.key, o.value)
+	}
+}
+
+// Get looks up a key's value from the cache, marking it visited.
+func (c *SIEVE[K, V]) Get(key K) (value V, ok bool) {
+	if n, ok := c.items[key]; ok {
+		n.visited = true
+		return n.value, true
+	}
+	return
+}
+
+// Contains checks if a key is in the cache, without updating its visited
+// bit.
+func (c *SIEVE[K, V]) Contains(key K) (ok bool) {
+	_, ok = c.items[key]
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the visited bit.
+func (c *SIEVE[K, V]) Peek(key K) (value V, ok bool) {
+	if n, ok := c.items[key]; ok {
+		return n.value, true
+	}
+	return value, false
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *SIEVE[K, V]) Remove(key K) (present bool) {
+	if n, ok := c.items[key]; ok {
+		c.unlink(n)
+		delete(c.items, n.key)
+		c.len--
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value)
+		}
+		return true
+	}
+	return false
+}
+
+// Len returns the number of items in the cache.
+func (c *SIEVE[K, V]) Len() int {
+	return c.len
+}
+
+// Resize changes the cache size.
+func (c *SIEVE[K, V]) Resize(size int) (evicted int) {
+	diff := c.len - size
+	if diff < 0 {
+		diff = 0
+	}
+	for i := 0; i < diff; i++ {
+		c.evict()
+	}
+	c.size = size
+	return diff
+}
+
+// SyncSIEVE is a thread-safe wrapper around SIEVE.
+//
+// Eviction callbacks are buffered while the lock is held and invoked only
+// after it is released (the same pattern lru.Cache uses), so a callback
+// that calls back into this SyncSIEVE, or acquires another lock, cannot
+// deadlock against its own mutex.
+type SyncSIEVE[K comparable, V any] struct {
+	lock    sync.Mutex
+	sieve   *SIEVE[K, V]
+	onEvict EvictCallback[K, V]
+
+	evictedKeys   []K
+	evictedValues []V
+}
+
+// NewSyncSIEVE constructs a thread-safe SIEVE cache of the given size.
+func NewSyncSIEVE[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*SyncSIEVE[K, V], error) {
+	c := &SyncSIEVE[K, V]{
+		onEvict: onEvict,
+	}
+	var cb EvictCallback[K, V]
+	if onEvict != nil {
+		c.initEvictBuffers()
+		cb = c.onEvicted
+	}
+	sieve, err := NewSIEVE[K, V](size, cb)
+	if err != nil {
+		return nil, err
+	}
+	c.sieve = sieve
+	return c, nil
+}
+
+func (c *SyncSIEVE[K, V]) initEvictBuffers() {
+	c.evictedKeys = make([]K, 0, 16)
+	c.evictedValues = make([]V, 0, 16)
+}
+
+// onEvicted is registered as the SIEVE's EvictCallback when this cache has
+// its own onEvict set; it must only be called while c.lock is held, and
+// buffers rather than invokes the user's callback so that the callback
+// never runs with the lock held.
+func (c *SyncSIEVE[K, V]) onEvicted(k K, v V) {
+	c.evictedKeys = append(c.evictedKeys, k)
+	c.evictedValues = append(c.evictedValues, v)
+}
+
+// finishCallback drains the evicted buffers and invokes onEvict for each
+// entry. Must be called after c.lock has been released; it re-takes the
+// lock only to swap the buffers out, and invokes onEvict without it.
+//
+// It takes ownership of the current buffers and installs fresh ones before
+// invoking any callback, rather than truncating c.evictedKeys/Values in
+// place after the loop: onEvict is free to call back into the cache, which
+// can trigger another eviction and call finishCallback again, and that
+// nested call must see an empty buffer rather than re-draining (and then
+// truncating out from under) entries the outer call is still iterating
+// over.
+func (c *SyncSIEVE[K, V]) finishCallback() {
+	if c.onEvict == nil {
+		return
+	}
+	c.lock.Lock()
+	keys, values := c.evictedKeys, c.evictedValues
+	if len(keys) > 0 {
+		c.initEvictBuffers()
+	}
+	c.lock.Unlock()
+	for i := range keys {
+		c.onEvict(keys[i], values[i])
+	}
+}
+
+// Purge is used to completely clear the cache.
+func (c *SyncSIEVE[K, V]) Purge() {
+	c.lock.Lock()
+	c.sieve.Purge()
+	c.lock.Unlock()
+	c.finishCallback()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *SyncSIEVE[K, V]) Add(key K, value V) (evicted bool) {
+	c.lock.Lock()
+	evicted = c.sieve.Add(key, value)
+	c.lock.Unlock()
+	c.finishCallback()
+	return evicted
+}
+
+// Get looks up a key's value from the cache, marking it visited.
+func (c *SyncSIEVE[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating its visited
+// bit.
+func (c *SyncSIEVE[K, V]) Contains(key K) (ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the visited bit.
+func (c *SyncSIEVE[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Peek(key)
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *SyncSIEVE[K, V]) Remove(key K) (present bool) {
+	c.lock.Lock()
+	present = c.sieve.Remove(key)
+	c.lock.Unlock()
+	c.finishCallback()
+	return present
+}
+
+// Len returns the number of items in the cache.
+func (c *SyncSIEVE[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Len()
+}
+
+// Resize changes the cache size.
+func (c *SyncSIEVE[K, V]) Resize(size int) (evicted int) {
+	c.lock.Lock()
+	evicted = c.sieve.Resize(size)
+	c.lock.Unlock()
+	c.finishCallback()
+	return evicted
+}
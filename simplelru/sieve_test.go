@@ -0,0 +1,103 @@
+package simplelru
+
+import "testing"
+
+func TestSIEVEEvictsUnvisitedBeforeVisited(t *testing.T) {
+	c, err := NewSIEVE[int, int](2, nil)
+	if err != nil {
+		t.Fatalf("NewSIEVE: %v", err)
+	}
+	c.Add(1, 1)
+	c.Add(2, 2)
+	c.Get(1) // mark 1 visited; 2 stays unvisited
+
+	if !c.Add(3, 3) {
+		t.Fatalf("expected eviction on third Add")
+	}
+
+	if _, ok := c.Get(2); ok {
+		t.Fatalf("expected unvisited entry 2 to be evicted")
+	}
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("expected visited entry 1 to survive")
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Fatalf("expected newly added entry 3 to be present")
+	}
+}
+
+func TestSIEVEHandRepairedOnRemove(t *testing.T) {
+	c, err := NewSIEVE[int, int](3, nil)
+	if err != nil {
+		t.Fatalf("NewSIEVE: %v", err)
+	}
+	c.Add(1, 1)
+	c.Add(2, 2)
+	c.Add(3, 3)
+	c.Get(1)
+	// The hand clears 1's visited bit, evicts 2 and is parked on 2's
+	// predecessor, 3.
+	c.Add(4, 4)
+
+	// Remove the node the hand is parked on; unlink must move the hand to
+	// 3's predecessor (4) rather than leave it dangling.
+	c.Remove(3)
+
+	c.Add(5, 5)
+	c.Add(6, 6) // triggers an eviction; must not touch the unlinked node
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries after evict, got %d", c.Len())
+	}
+	if c.Contains(4) {
+		t.Fatalf("expected the repaired hand to evict 4")
+	}
+}
+
+func TestSyncSIEVEReentrantEvictCallback(t *testing.T) {
+	var cache *SyncSIEVE[int, int]
+	var sawKeys []int
+
+	cache, err := NewSyncSIEVE[int, int](2, func(key, value int) {
+		sawKeys = append(sawKeys, key)
+		// A callback that calls back into the cache must not deadlock.
+		cache.Contains(999)
+	})
+	if err != nil {
+		t.Fatalf("NewSyncSIEVE: %v", err)
+	}
+
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+	cache.Add(3, 3) // forces an eviction; onEvict re-enters the cache
+
+	if len(sawKeys) != 1 {
+		t.Fatalf("expected exactly one eviction callback, got %d", len(sawKeys))
+	}
+}
+
+func TestSyncSIEVEReentrantEvictCallbackTriggersEviction(t *testing.T) {
+	var cache *SyncSIEVE[int, int]
+	var sawKeys []int
+	reentered := false
+
+	cache, err := NewSyncSIEVE[int, int](2, func(key, value int) {
+		sawKeys = append(sawKeys, key)
+		// Unlike TestSyncSIEVEReentrantEvictCallback, this callback's
+		// re-entrant call itself causes another eviction.
+		if !reentered {
+			reentered = true
+			cache.Add(100, 100)
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewSyncSIEVE: %v", err)
+	}
+
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+	cache.Add(3, 3) // forces an eviction; onEvict re-enters with another Add
+
+	if len(sawKeys) != 2 {
+		t.Fatalf("expected exactly two eviction callbacks, got %v", sawKeys)
+	}
+}